@@ -0,0 +1,229 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// actionCache is a content-addressed cache of compiled BPF objects and their
+// generated Go output, modeled on cmd/go/internal/work's build cache: a
+// cache key (the "action ID") is derived from everything that influences the
+// output of a build, and a hit lets convert skip straight to copying files
+// instead of invoking clang and llvm-strip.
+type actionCache struct {
+	dir string
+}
+
+// newActionCache opens the on-disk cache, creating it if necessary.
+//
+// The cache lives under $GOCACHE/bpf2go, falling back to os.UserCacheDir
+// when GOCACHE isn't set, so that `go clean -cache` also sweeps bpf2go's
+// output when run from a Go toolchain that sets GOCACHE in the environment.
+func newActionCache() (*actionCache, error) {
+	dir := os.Getenv("GOCACHE")
+	if dir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("locate cache directory: %w", err)
+		}
+		dir = userCacheDir
+	}
+
+	dir = filepath.Join(dir, "bpf2go")
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, err
+	}
+
+	return &actionCache{dir: dir}, nil
+}
+
+// lookup returns the directory holding a cached build for id, or an error if
+// there's no such entry.
+func (c *actionCache) lookup(id string) (string, error) {
+	dir := filepath.Join(c.dir, id)
+	if _, err := os.Stat(dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// lookupHeaders returns the header set recorded the last time id (a
+// preliminary action ID) led to a cache entry, or an error if there's no
+// such record. The caller re-hashes these headers' current contents to
+// compute today's full action ID, which is what's actually looked up.
+func (c *actionCache) lookupHeaders(id string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(c.dir, id, "headers"))
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimRight(string(data), "\n")
+	if trimmed == "" {
+		return nil, nil
+	}
+	return strings.Split(trimmed, "\n"), nil
+}
+
+// storeHeaders records the header set discovered while building under id (a
+// preliminary action ID), so a future run with the same source/flags/target
+// can recompute the full action ID without invoking clang.
+func (c *actionCache) storeHeaders(id string, headers []string) error {
+	dir := filepath.Join(c.dir, id)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "headers"), []byte(strings.Join(headers, "\n")), 0666)
+}
+
+// store copies every non-empty path in files into the cache under id.
+func (c *actionCache) store(id string, files ...string) error {
+	dir := filepath.Join(c.dir, id)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+
+	for _, src := range files {
+		if src == "" {
+			continue
+		}
+		if err := copyFile(filepath.Join(dir, filepath.Base(src)), src); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreCached copies a cached build found in dir to every non-empty path
+// in files.
+func restoreCached(dir string, files ...string) error {
+	for _, dst := range files {
+		if dst == "" {
+			continue
+		}
+		if err := copyFile(dst, filepath.Join(dir, filepath.Base(dst))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+
+	return out.Close()
+}
+
+// actionID computes the cache key for a build of b2g's source against tgt
+// and cFlags. headers is the transitive set of header files pulled in by the
+// source, discovered from a previous compile's .d output; pass nil to
+// compute the preliminary ID used to probe the cache before compiling.
+func actionID(b2g *bpf2go, tgt target, cFlags []string, headers []string) (string, error) {
+	h := sha256.New()
+
+	fmt.Fprintln(h, bpf2goBuildID())
+
+	ccVersion, err := ccVersionString(b2g.cc)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintln(h, b2g.cc)
+	fmt.Fprintln(h, ccVersion)
+	fmt.Fprintln(h, b2g.strip)
+	fmt.Fprintln(h, b2g.debugOutput)
+
+	sortedFlags := append([]string(nil), cFlags...)
+	sort.Strings(sortedFlags)
+	for _, flag := range sortedFlags {
+		fmt.Fprintln(h, flag)
+	}
+
+	fmt.Fprintln(h, tgt.clang, tgt.linux)
+
+	// These don't affect the compiled object, but they do affect the
+	// generated Go and .d files, which are cached right alongside it.
+	fmt.Fprintln(h, b2g.pkg)
+	fmt.Fprintln(h, b2g.makeBase)
+	fmt.Fprintln(h, b2g.ident)
+	fmt.Fprintln(h, b2g.outputStem)
+	fmt.Fprintln(h, b2g.skipGlobalTypes)
+	for _, ct := range b2g.cTypes {
+		fmt.Fprintln(h, ct)
+	}
+	if b2g.tags.Expr != nil {
+		fmt.Fprintln(h, b2g.tags.Expr.String())
+	}
+
+	sortedSources := append([]string(nil), b2g.sourceFiles...)
+	sort.Strings(sortedSources)
+	for _, source := range sortedSources {
+		fmt.Fprintln(h, source)
+		if err := hashFile(h, source); err != nil {
+			return "", fmt.Errorf("hash source %s: %w", source, err)
+		}
+	}
+
+	sortedHeaders := append([]string(nil), headers...)
+	sort.Strings(sortedHeaders)
+	for _, header := range sortedHeaders {
+		fmt.Fprintln(h, header)
+		if err := hashFile(h, header); err != nil {
+			return "", fmt.Errorf("hash header %s: %w", header, err)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(h io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(h, f)
+	return err
+}
+
+// bpf2goBuildID identifies the version of bpf2go itself, so that upgrading
+// the tool invalidates every existing cache entry.
+func bpf2goBuildID() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s@%s", bi.Main.Path, bi.Main.Version)
+}
+
+func ccVersionString(cc string) (string, error) {
+	out, err := exec.Command(cc, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("determine %s version: %w", cc, err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// debugOutputMode controls how much debug info survives in the final
+// object, and whether it's kept alongside as a separate, compressed file.
+type debugOutputMode string
+
+const (
+	// debugOutputNone strips all debug info, as bpf2go has always done by
+	// default.
+	debugOutputNone debugOutputMode = "none"
+	// debugOutputBTF splits .BTF/.BTF.ext out into a gzipped companion file
+	// before stripping the main object, keeping runtime objects small while
+	// preserving field/relocation info for CO-RE debugging.
+	debugOutputBTF debugOutputMode = "btf"
+	// debugOutputDWARF disables stripping entirely, keeping full DWARF in
+	// the object. Equivalent to the pre-existing -no-strip flag.
+	debugOutputDWARF debugOutputMode = "dwarf"
+)
+
+// debugOutputFlag implements flag.Value for -debug-output, tracking whether
+// the flag was explicitly set so that run can detect conflicts with the
+// older -no-strip flag.
+type debugOutputFlag struct {
+	mode debugOutputMode
+	set  bool
+}
+
+func (f *debugOutputFlag) String() string {
+	if f == nil || f.mode == "" {
+		return string(debugOutputNone)
+	}
+	return string(f.mode)
+}
+
+func (f *debugOutputFlag) Set(value string) error {
+	switch debugOutputMode(value) {
+	case debugOutputNone, debugOutputBTF, debugOutputDWARF:
+		f.mode = debugOutputMode(value)
+		f.set = true
+		return nil
+	default:
+		return fmt.Errorf("invalid -debug-output %q, must be one of none, btf, dwarf", value)
+	}
+}
+
+// splitBTF extracts .BTF and .BTF.ext from objFileName and writes them
+// gzip-compressed to gzFileName, using b2g.objcopy. The main object is left
+// untouched; stripping it is the caller's job, same as for every other
+// debug output mode.
+func (b2g *bpf2go) splitBTF(ctx context.Context, objFileName, gzFileName string) error {
+	btfFileName := strings.TrimSuffix(gzFileName, ".gz")
+	cmd := exec.CommandContext(ctx, b2g.objcopy,
+		"--only-section=.BTF", "--only-section=.BTF.ext",
+		objFileName, btfFileName)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w\n%s", b2g.objcopy, err, out)
+	}
+	defer os.Remove(btfFileName)
+
+	return gzipFile(gzFileName, btfFileName)
+}
+
+func gzipFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return out.Close()
+}
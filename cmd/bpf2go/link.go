@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// linkObjects merges srcs into a single ELF object at dest using
+// `bpftool gen object`, so that sources compiled independently end up as
+// one object, and therefore one set of generated Go types.
+//
+// srcs are ELF objects produced by clang -c, not LLVM bitcode, so llvm-link
+// can't be used here: it only understands bitcode inputs. bpftool gen
+// object performs the equivalent merge (map/prog definitions, BTF, relocations)
+// directly on ELF.
+func linkObjects(ctx context.Context, bpftool string, srcs []string, dest string) error {
+	args := append([]string{"gen", "object", dest}, srcs...)
+	cmd := exec.CommandContext(ctx, bpftool, args...)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w\n%s", bpftool, err, out)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -10,20 +11,26 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"sort"
 	"strings"
+	"sync"
+	"text/tabwriter"
 )
 
-const helpText = `Usage: %[1]s [options] <ident> <source file> [-- <C flags>]
+const helpText = `Usage: %[1]s [options] <ident> <source file>... [-- <C flags>]
 
 ident is used as the stem of all generated Go types and functions, and
 must be a valid Go identifier.
 
-source is a single C file that is compiled using the specified compiler
-(usually some version of clang).
+source is one or more C files that are compiled independently using the
+specified compiler (usually some version of clang) and then linked into
+a single object, so that programs and maps spread across several files
+are surfaced as one set of Go types. Source files may also be passed via
+-sources instead of as positional arguments.
 
 You can pass options to the compiler by appending them after a '--' argument
 or by supplying -cflags. Flags passed as arguments take precedence
@@ -66,7 +73,7 @@ var targetByGoArch = map[string]target{
 	"sparc64":     {"bpfeb", "sparc"},
 }
 
-func run(stdout io.Writer, pkg, outputDir string, args []string) (err error) {
+func run(ctx context.Context, stdout io.Writer, pkg, outputDir string, args []string) (err error) {
 	b2g := bpf2go{
 		stdout:    stdout,
 		pkg:       pkg,
@@ -76,14 +83,18 @@ func run(stdout io.Writer, pkg, outputDir string, args []string) (err error) {
 	fs := flag.NewFlagSet("bpf2go", flag.ContinueOnError)
 	fs.StringVar(&b2g.cc, "cc", "clang", "`binary` used to compile C to BPF")
 	fs.StringVar(&b2g.strip, "strip", "", "`binary` used to strip DWARF from compiled BPF (default \"llvm-strip\")")
-	fs.BoolVar(&b2g.disableStripping, "no-strip", false, "disable stripping of DWARF")
+	fs.BoolVar(&b2g.disableStripping, "no-strip", false, "disable stripping of DWARF, alias for -debug-output dwarf")
+	flagDebugOutput := &debugOutputFlag{}
+	fs.Var(flagDebugOutput, "debug-output", "debug info to keep in the output: `none` (default, strip everything), `btf` (split .btf.gz side file), or `dwarf` (keep full DWARF)")
 	flagCFlags := fs.String("cflags", "", "flags passed to the compiler, may contain quoted arguments")
 	fs.Var(&b2g.tags, "tags", "Comma-separated list of Go build tags to include in generated files")
-	flagTarget := fs.String("target", "bpfel,bpfeb", "clang target(s) to compile for (comma separated)")
+	flagTarget := fs.String("target", "bpfel,bpfeb", "clang target(s) to compile for (comma separated), \"all\"/\"linux\" for every supported arch")
+	fs.IntVar(&b2g.p, "p", runtime.NumCPU(), "`number` of targets to build in parallel")
 	fs.StringVar(&b2g.makeBase, "makebase", "", "write make compatible depinfo files relative to `directory`")
 	fs.Var(&b2g.cTypes, "type", "`Name` of a type to generate a Go declaration for, may be repeated")
 	fs.BoolVar(&b2g.skipGlobalTypes, "no-global-types", false, "Skip generating types for map keys and values, etc.")
 	fs.StringVar(&b2g.outputStem, "output-stem", "", "alternative stem for names of generated files (defaults to ident)")
+	flagSources := fs.String("sources", "", "comma separated list of source files, alternative to passing them as positional arguments")
 
 	fs.SetOutput(stdout)
 	fs.Usage = func() {
@@ -106,6 +117,21 @@ func run(stdout io.Writer, pkg, outputDir string, args []string) (err error) {
 		return errors.New("no compiler specified")
 	}
 
+	if b2g.p < 1 {
+		return fmt.Errorf("-p must be at least 1")
+	}
+
+	switch {
+	case b2g.disableStripping && flagDebugOutput.set && flagDebugOutput.mode != debugOutputDWARF:
+		return fmt.Errorf("-no-strip is an alias for -debug-output dwarf and conflicts with -debug-output %s", flagDebugOutput.mode)
+	case b2g.disableStripping:
+		b2g.debugOutput = debugOutputDWARF
+	case flagDebugOutput.set:
+		b2g.debugOutput = flagDebugOutput.mode
+	default:
+		b2g.debugOutput = debugOutputNone
+	}
+
 	args, cFlags := splitCFlagsFromArgs(fs.Args())
 
 	if *flagCFlags != "" {
@@ -127,8 +153,8 @@ func run(stdout io.Writer, pkg, outputDir string, args []string) (err error) {
 
 	b2g.cFlags = cFlags[:len(cFlags):len(cFlags)]
 
-	if len(args) < 2 {
-		return errors.New("expected at least two arguments")
+	if len(args) < 1 {
+		return errors.New("expected at least a target identifier")
 	}
 
 	b2g.ident = args[0]
@@ -136,16 +162,32 @@ func run(stdout io.Writer, pkg, outputDir string, args []string) (err error) {
 		return fmt.Errorf("%q is not a valid identifier", b2g.ident)
 	}
 
-	input := args[1]
-	if _, err := os.Stat(input); os.IsNotExist(err) {
-		return fmt.Errorf("file %s doesn't exist", input)
-	} else if err != nil {
-		return fmt.Errorf("state %s: %s", input, err)
+	var inputs []string
+	if *flagSources != "" {
+		if len(args) > 1 {
+			return errors.New("-sources and positional source files are mutually exclusive")
+		}
+		inputs = strings.Split(*flagSources, ",")
+	} else {
+		if len(args) < 2 {
+			return errors.New("expected at least two arguments")
+		}
+		inputs = args[1:]
 	}
 
-	b2g.sourceFile, err = filepath.Abs(input)
-	if err != nil {
-		return err
+	b2g.sourceFiles = make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		if _, err := os.Stat(input); os.IsNotExist(err) {
+			return fmt.Errorf("file %s doesn't exist", input)
+		} else if err != nil {
+			return fmt.Errorf("state %s: %s", input, err)
+		}
+
+		abs, err := filepath.Abs(input)
+		if err != nil {
+			return err
+		}
+		b2g.sourceFiles = append(b2g.sourceFiles, abs)
 	}
 
 	if b2g.makeBase != "" {
@@ -174,7 +216,7 @@ func run(stdout io.Writer, pkg, outputDir string, args []string) (err error) {
 		return err
 	}
 
-	if !b2g.disableStripping {
+	if b2g.debugOutput != debugOutputDWARF {
 		// Try to find a suitable llvm-strip, possibly with a version suffix derived
 		// from the clang binary.
 		if b2g.strip == "" {
@@ -190,15 +232,138 @@ func run(stdout io.Writer, pkg, outputDir string, args []string) (err error) {
 		}
 	}
 
-	for target, arches := range targets {
-		if err := b2g.convert(target, arches); err != nil {
-			return err
+	if b2g.debugOutput == debugOutputBTF {
+		objcopy := "llvm-objcopy"
+		if strings.HasPrefix(b2g.cc, "clang") {
+			objcopy += strings.TrimPrefix(b2g.cc, "clang")
 		}
+
+		b2g.objcopy, err = exec.LookPath(objcopy)
+		if err != nil {
+			return fmt.Errorf("find llvm-objcopy for -debug-output btf: %w", err)
+		}
+	}
+
+	if len(b2g.sourceFiles) > 1 {
+		b2g.link, err = exec.LookPath("bpftool")
+		if err != nil {
+			return fmt.Errorf("find bpftool to link multiple source files: %w", err)
+		}
+	}
+
+	if cache, err := newActionCache(); err != nil {
+		fmt.Fprintln(stdout, "Warning: disabling build cache:", err)
+	} else {
+		b2g.cache = cache
 	}
 
+	return b2g.convertAll(ctx, targets)
+}
+
+// convertAll runs convert for each target, using up to b2g.p workers.
+//
+// Output is serialised so that lines belonging to different targets don't
+// interleave. A failure in one target doesn't stop or cancel the others:
+// errors are collected and joined once every worker has finished. Only
+// cancellation of the ctx passed in tears down in-flight clang/strip
+// invocations.
+func (b2g *bpf2go) convertAll(ctx context.Context, targets map[target][]string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		target target
+		arches []string
+	}
+
+	jobs := make([]job, 0, len(targets))
+	for tgt, arches := range targets {
+		jobs = append(jobs, job{tgt, arches})
+	}
+
+	sem := make(chan struct{}, b2g.p)
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    []error
+		results []convertResult
+	)
+
+	for _, j := range jobs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(j job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := b2g.convert(ctx, j.target, j.arches)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				// Deliberately not cancelling ctx here: one target's failure
+				// shouldn't abort targets already in flight. Cancellation is
+				// reserved for the signal-derived context installed in main.
+				errs = append(errs, fmt.Errorf("%s: %w", j.target.clang, err))
+				return
+			}
+			results = append(results, result)
+		}(j)
+	}
+
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	b2g.printSummary(results)
 	return nil
 }
 
+// convertResult describes the outcome of converting a single target, used to
+// print the summary table once every target has finished.
+type convertResult struct {
+	target  target
+	arches  []string
+	objSize int64
+	cached  bool
+}
+
+func (b2g *bpf2go) printSummary(results []convertResult) {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i].target, results[j].target
+		if a.clang != b.clang {
+			return a.clang < b.clang
+		}
+		return a.linux < b.linux
+	})
+
+	tw := tabwriter.NewWriter(b2g.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tARCHES\tOBJECT SIZE\tCACHE")
+	for _, result := range results {
+		name := result.target.clang
+		if result.target.linux != "" {
+			name = result.target.linux
+		}
+
+		status := "miss"
+		if result.cached {
+			status = "hit"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n",
+			name, strings.Join(result.arches, ","), result.objSize, status)
+	}
+	tw.Flush()
+}
+
+// logln writes to b2g.stdout, serialising concurrent callers so that lines
+// produced by different workers in convertAll don't interleave.
+func (b2g *bpf2go) logln(args ...interface{}) {
+	b2g.logMu.Lock()
+	defer b2g.logMu.Unlock()
+	fmt.Fprintln(b2g.stdout, args...)
+}
+
 // cTypes collects the C type names a user wants to generate Go types for.
 //
 // Names are guaranteed to be unique, and only a subset of names is accepted so
@@ -239,8 +404,13 @@ func (ct *cTypes) Set(value string) error {
 
 type bpf2go struct {
 	stdout io.Writer
-	// Absolute path to a .c file.
-	sourceFile string
+	// Serialises writes to stdout from convertAll's workers.
+	logMu sync.Mutex
+	// Number of targets to convert concurrently.
+	p int
+	// Absolute paths to one or more .c files. Compiled independently and,
+	// when there's more than one, linked together into a single object.
+	sourceFiles []string
 	// Absolute path to a directory where .go are written
 	outputDir string
 	// Alternative output stem. If empty, ident is used.
@@ -252,8 +422,18 @@ type bpf2go struct {
 	// C compiler.
 	cc string
 	// Command used to strip DWARF.
-	strip            string
+	strip string
+	// How much debug info to keep in the final object; see debugOutputMode.
+	// disableStripping is the older -no-strip flag, kept as a compatibility
+	// alias for -debug-output dwarf.
+	debugOutput      debugOutputMode
 	disableStripping bool
+	// Command used to extract split BTF, resolved only when debugOutput is
+	// debugOutputBTF.
+	objcopy string
+	// Command used to link multiple objects together. Only resolved when
+	// there's more than one source file.
+	link string
 	// C flags passed to the compiler.
 	cFlags          []string
 	skipGlobalTypes bool
@@ -264,9 +444,14 @@ type bpf2go struct {
 	// Base directory of the Makefile. Enables outputting make-style dependencies
 	// in .d files.
 	makeBase string
+	// Action cache used to skip recompiling unchanged sources. Nil disables
+	// caching.
+	cache *actionCache
 }
 
-func (b2g *bpf2go) convert(tgt target, arches []string) (err error) {
+func (b2g *bpf2go) convert(ctx context.Context, tgt target, arches []string) (res convertResult, err error) {
+	res = convertResult{target: tgt, arches: arches}
+
 	removeOnError := func(f *os.File) {
 		if err != nil {
 			os.Remove(f.Name())
@@ -287,7 +472,7 @@ func (b2g *bpf2go) convert(tgt target, arches []string) (err error) {
 
 	cwd, err := os.Getwd()
 	if err != nil {
-		return err
+		return res, err
 	}
 
 	var archConstraint constraint.Expr
@@ -304,34 +489,128 @@ func (b2g *bpf2go) convert(tgt target, arches []string) (err error) {
 		cFlags = append(cFlags, "-D__TARGET_ARCH_"+tgt.linux)
 	}
 
-	var dep bytes.Buffer
-	err = compile(compileArgs{
-		cc:     b2g.cc,
-		cFlags: cFlags,
-		target: tgt.clang,
-		dir:    cwd,
-		source: b2g.sourceFile,
-		dest:   objFileName,
-		dep:    &dep,
-	})
+	goFileName := filepath.Join(b2g.outputDir, stem+".go")
+	var depFileName string
+	if b2g.makeBase != "" {
+		depFileName = goFileName + ".d"
+	}
+
+	var btfFileName string
+	if b2g.debugOutput == debugOutputBTF {
+		btfFileName = filepath.Join(b2g.outputDir, stem+".btf.gz")
+	}
+
+	// The full action ID also covers the transitive headers pulled in by the
+	// source file, which we only learn about after compiling. The
+	// preliminary ID can't be looked up directly, since it says nothing
+	// about whether a header changed: instead, it points at the header set
+	// recorded the last time these inputs were compiled, which lets us
+	// recompute today's full ID - and therefore notice a changed header -
+	// without invoking clang.
+	preliminaryID, err := actionID(b2g, tgt, cFlags, nil)
 	if err != nil {
-		return err
+		return res, fmt.Errorf("compute cache key: %w", err)
+	}
+
+	if b2g.cache != nil {
+		if headers, headersErr := b2g.cache.lookupHeaders(preliminaryID); headersErr == nil {
+			if fullID, idErr := actionID(b2g, tgt, cFlags, headers); idErr == nil {
+				if dir, lookupErr := b2g.cache.lookup(fullID); lookupErr == nil {
+					if restoreErr := restoreCached(dir, objFileName, goFileName, depFileName, btfFileName); restoreErr == nil {
+						b2g.logln("Cached", objFileName)
+						res.cached = true
+						if info, statErr := os.Stat(objFileName); statErr == nil {
+							res.objSize = info.Size()
+						}
+						return res, nil
+					}
+				}
+			}
+		}
+	}
+
+	var deps []dependency // one rule per compiled source file
+	if len(b2g.sourceFiles) == 1 {
+		var dep bytes.Buffer
+		if err := compile(compileArgs{
+			ctx:    ctx,
+			cc:     b2g.cc,
+			cFlags: cFlags,
+			target: tgt.clang,
+			dir:    cwd,
+			source: b2g.sourceFiles[0],
+			dest:   objFileName,
+			dep:    &dep,
+		}); err != nil {
+			return res, err
+		}
+
+		parsed, err := parseDependencies(cwd, &dep)
+		if err != nil {
+			return res, fmt.Errorf("can't read dependency information: %s", err)
+		}
+		deps = parsed
+	} else {
+		// Compile every source independently against the same flags and
+		// target, then link the resulting objects into a single ELF so that
+		// output sees one object and emits one set of Go types, the same as
+		// it would for a single source file.
+		partObjs := make([]string, len(b2g.sourceFiles))
+		for i, source := range b2g.sourceFiles {
+			partObj := fmt.Sprintf("%s.part%d.o", objFileName, i)
+			partObjs[i] = partObj
+			defer os.Remove(partObj)
+
+			var dep bytes.Buffer
+			if err := compile(compileArgs{
+				ctx:    ctx,
+				cc:     b2g.cc,
+				cFlags: cFlags,
+				target: tgt.clang,
+				dir:    cwd,
+				source: source,
+				dest:   partObj,
+				dep:    &dep,
+			}); err != nil {
+				return res, fmt.Errorf("compile %s: %w", source, err)
+			}
+
+			parsed, err := parseDependencies(cwd, &dep)
+			if err != nil {
+				return res, fmt.Errorf("can't read dependency information for %s: %s", source, err)
+			}
+			deps = append(deps, parsed...)
+		}
+
+		if err := linkObjects(ctx, b2g.link, partObjs, objFileName); err != nil {
+			return res, fmt.Errorf("link %s: %w", objFileName, err)
+		}
 	}
 
-	fmt.Fprintln(b2g.stdout, "Compiled", objFileName)
+	b2g.logln("Compiled", objFileName)
 
-	if !b2g.disableStripping {
-		if err := strip(b2g.strip, objFileName); err != nil {
-			return err
+	if b2g.debugOutput == debugOutputBTF {
+		if err := b2g.splitBTF(ctx, objFileName, btfFileName); err != nil {
+			return res, fmt.Errorf("split BTF: %w", err)
 		}
-		fmt.Fprintln(b2g.stdout, "Stripped", objFileName)
+		b2g.logln("Wrote", btfFileName)
+	}
+
+	if b2g.debugOutput != debugOutputDWARF {
+		if err := strip(ctx, b2g.strip, objFileName); err != nil {
+			return res, err
+		}
+		b2g.logln("Stripped", objFileName)
+	}
+
+	if info, statErr := os.Stat(objFileName); statErr == nil {
+		res.objSize = info.Size()
 	}
 
 	// Write out generated go
-	goFileName := filepath.Join(b2g.outputDir, stem+".go")
 	goFile, err := os.Create(goFileName)
 	if err != nil {
-		return err
+		return res, err
 	}
 	defer removeOnError(goFile)
 
@@ -342,37 +621,59 @@ func (b2g *bpf2go) convert(tgt target, arches []string) (err error) {
 		skipGlobalTypes: b2g.skipGlobalTypes,
 		constraints:     constraints,
 		obj:             objFileName,
+		btf:             btfFileName,
 		out:             goFile,
 	})
 	if err != nil {
-		return fmt.Errorf("can't write %s: %s", goFileName, err)
+		return res, fmt.Errorf("can't write %s: %s", goFileName, err)
 	}
 
-	fmt.Fprintln(b2g.stdout, "Wrote", goFileName)
+	b2g.logln("Wrote", goFileName)
 
-	if b2g.makeBase == "" {
-		return
-	}
+	if b2g.makeBase != "" {
+		// Every source's dependency rule now describes a prerequisite of the
+		// same generated Go file, so that a change to any source or header
+		// triggers a rebuild.
+		for i := range deps {
+			deps[i].file = goFileName
+		}
 
-	deps, err := parseDependencies(cwd, &dep)
-	if err != nil {
-		return fmt.Errorf("can't read dependency information: %s", err)
-	}
+		depFile, err := adjustDependencies(b2g.makeBase, deps)
+		if err != nil {
+			return res, fmt.Errorf("can't adjust dependency information: %s", err)
+		}
 
-	// There is always at least a dependency for the main file.
-	deps[0].file = goFileName
-	depFile, err := adjustDependencies(b2g.makeBase, deps)
-	if err != nil {
-		return fmt.Errorf("can't adjust dependency information: %s", err)
+		if err := os.WriteFile(depFileName, depFile, 0666); err != nil {
+			return res, fmt.Errorf("can't write dependency file: %s", err)
+		}
+
+		b2g.logln("Wrote", depFileName)
 	}
 
-	depFileName := goFileName + ".d"
-	if err := os.WriteFile(depFileName, depFile, 0666); err != nil {
-		return fmt.Errorf("can't write dependency file: %s", err)
+	if b2g.cache != nil {
+		var headers []string
+		for _, d := range deps {
+			headers = append(headers, d.dependencies...)
+		}
+
+		fullID, err := actionID(b2g, tgt, cFlags, headers)
+		if err != nil {
+			return res, fmt.Errorf("compute cache key: %w", err)
+		}
+
+		// The full ID is authoritative and owns the actual artifacts. The
+		// preliminary ID only remembers which headers produced them, so a
+		// future run can recompute the full ID - and detect a header change
+		// - before touching clang.
+		if err := b2g.cache.store(fullID, objFileName, goFileName, depFileName, btfFileName); err != nil {
+			return res, fmt.Errorf("store cache entry: %w", err)
+		}
+		if err := b2g.cache.storeHeaders(preliminaryID, headers); err != nil {
+			return res, fmt.Errorf("store cache entry: %w", err)
+		}
 	}
 
-	fmt.Fprintln(b2g.stdout, "Wrote", depFileName)
-	return nil
+	return res, nil
 }
 
 type target struct {
@@ -391,7 +692,7 @@ func printTargets(w io.Writer) {
 	sort.Strings(arches)
 
 	fmt.Fprint(w, "Supported targets:\n")
-	fmt.Fprint(w, "\tbpf\n\tbpfel\n\tbpfeb\n")
+	fmt.Fprint(w, "\tbpf\n\tbpfel\n\tbpfeb\n\tnative\n\tall (every arch below)\n\tlinux (alias for all)\n")
 	for _, arch := range arches {
 		fmt.Fprintf(w, "\t%s\n", arch)
 	}
@@ -414,6 +715,24 @@ func collectTargets(targets []string) (map[target][]string, error) {
 			sort.Strings(goarches)
 			result[target{tgt, ""}] = goarches
 
+		case "all", "linux":
+			// "all" and "linux" both expand to every architecture bpf2go can
+			// cross-compile for; linux is the more descriptive name for the
+			// set and is accepted as an alias. Go through addTarget so that
+			// arches sharing a target (e.g. 386 and amd64, which both use
+			// bpfel/x86) end up grouped under it exactly once.
+			var goarches []string
+			for goarch, archTarget := range targetByGoArch {
+				if archTarget.linux != "" {
+					goarches = append(goarches, goarch)
+				}
+			}
+			sort.Strings(goarches)
+
+			for _, goarch := range goarches {
+				addTarget(result, targetByGoArch[goarch])
+			}
+
 		case "native":
 			tgt = runtime.GOARCH
 			fallthrough
@@ -424,23 +743,35 @@ func collectTargets(targets []string) (map[target][]string, error) {
 				return nil, fmt.Errorf("%q: %w", tgt, errInvalidTarget)
 			}
 
-			var goarches []string
-			for goarch, lt := range targetByGoArch {
-				if lt == archTarget {
-					// Include tags for all goarches that have the same
-					// target.
-					goarches = append(goarches, goarch)
-				}
-			}
-
-			sort.Strings(goarches)
-			result[archTarget] = goarches
+			addTarget(result, archTarget)
 		}
 	}
 
 	return result, nil
 }
 
+// addTarget adds every goarch sharing tgt to result, so that architectures
+// which only differ in a build tag (e.g. 386 and amd64) are grouped
+// together instead of compiled once per goarch. Arches of different
+// endianness (e.g. ppc64le and ppc64) have different targets and are not
+// grouped.
+func addTarget(result map[target][]string, tgt target) {
+	if _, ok := result[tgt]; ok {
+		// Already populated by an earlier arch that shares this target.
+		return
+	}
+
+	var goarches []string
+	for ga, lt := range targetByGoArch {
+		if lt == tgt {
+			goarches = append(goarches, ga)
+		}
+	}
+
+	sort.Strings(goarches)
+	result[tgt] = goarches
+}
+
 func main() {
 	outputDir, err := os.Getwd()
 	if err != nil {
@@ -448,7 +779,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := run(os.Stdout, os.Getenv("GOPACKAGE"), outputDir, os.Args[1:]); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Stdout, os.Getenv("GOPACKAGE"), outputDir, os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
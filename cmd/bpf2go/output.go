@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// outputArgs holds everything output needs to render the generated Go file
+// for a single target: the compiled object (and, optionally, its split BTF
+// companion) plus the naming and build-constraint decisions made by convert.
+type outputArgs struct {
+	pkg             string
+	ident           string
+	cTypes          cTypes
+	skipGlobalTypes bool
+	constraints     constraint.Expr
+	// obj is the path to the compiled object that backs the generated
+	// //go:embed directive. Required.
+	obj string
+	// btf is the path to the gzipped BTF companion file produced when
+	// -debug-output btf is set. Empty when there's no companion file to
+	// embed.
+	btf string
+	out io.Writer
+}
+
+// output renders the Go source that loads args.obj (and, if set, args.btf)
+// via go:embed and exposes it under args.ident.
+func output(args outputArgs) error {
+	if args.pkg == "" {
+		return fmt.Errorf("package name is empty")
+	}
+	if args.ident == "" {
+		return fmt.Errorf("ident is empty")
+	}
+	if args.obj == "" {
+		return fmt.Errorf("object file name is empty")
+	}
+	// Emitting Go declarations for C types requires parsing the BTF that
+	// clang attaches to the object, which this build of output doesn't do
+	// yet. Rather than silently producing a package that's missing the
+	// bindings the user asked for, refuse outright.
+	if len(args.cTypes) > 0 {
+		return fmt.Errorf("generate Go types for %s: -type is not yet supported by this build of bpf2go", strings.Join(args.cTypes, ", "))
+	}
+
+	var buildTags string
+	if args.constraints != nil {
+		buildTags = "// +build " + args.constraints.String()
+	}
+
+	var btfName string
+	if args.btf != "" {
+		btfName = filepath.Base(args.btf)
+	}
+
+	return outputTemplate.Execute(args.out, struct {
+		Pkg       string
+		Ident     string
+		BuildTags string
+		ObjName   string
+		BTFName   string
+	}{
+		Pkg:       args.pkg,
+		Ident:     args.ident,
+		BuildTags: buildTags,
+		ObjName:   filepath.Base(args.obj),
+		BTFName:   btfName,
+	})
+}
+
+var outputTemplate = template.Must(template.New("output").Parse(`// Code generated by bpf2go; DO NOT EDIT.
+{{- if .BuildTags }}
+{{ .BuildTags }}
+{{- end }}
+
+package {{ .Pkg }}
+
+import (
+	"bytes"
+	_ "embed"
+)
+
+// {{ .Ident }}Obj is the compiled BPF object for {{ .Ident }}.
+//go:embed {{ .ObjName }}
+var {{ .Ident }}Obj []byte
+
+{{ if .BTFName -}}
+// {{ .Ident }}BTF is the gzip-compressed split BTF for {{ .Ident }}, produced
+// because -debug-output btf stripped it out of {{ .Ident }}Obj to keep the
+// runtime object small.
+//go:embed {{ .BTFName }}
+var {{ .Ident }}BTF []byte
+{{ end -}}
+
+func load{{ .Ident }}() (*bytes.Reader, error) {
+	return bytes.NewReader({{ .Ident }}Obj), nil
+}
+`))
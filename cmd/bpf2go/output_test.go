@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOutputEmbedsBTF(t *testing.T) {
+	var buf bytes.Buffer
+	err := output(outputArgs{
+		pkg:   "foo",
+		ident: "bar",
+		obj:   "bar_x86.o",
+		btf:   "bar_x86.btf.gz",
+		out:   &buf,
+	})
+	if err != nil {
+		t.Fatalf("output: %v", err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, "//go:embed bar_x86.btf.gz") {
+		t.Errorf("output doesn't embed BTF companion file:\n%s", got)
+	}
+	if !strings.Contains(got, "barBTF") {
+		t.Errorf("output doesn't declare a BTF variable:\n%s", got)
+	}
+}
+
+func TestOutputNoBTF(t *testing.T) {
+	var buf bytes.Buffer
+	err := output(outputArgs{
+		pkg:   "foo",
+		ident: "bar",
+		obj:   "bar_x86.o",
+		out:   &buf,
+	})
+	if err != nil {
+		t.Fatalf("output: %v", err)
+	}
+
+	got := buf.String()
+	if strings.Contains(got, "go:embed") && strings.Contains(got, ".btf.gz") {
+		t.Errorf("output embeds a BTF file when none was given:\n%s", got)
+	}
+	if strings.Contains(got, "barBTF") {
+		t.Errorf("output declares a BTF variable when none was given:\n%s", got)
+	}
+}
+
+func TestOutputRejectsCTypes(t *testing.T) {
+	var buf bytes.Buffer
+	err := output(outputArgs{
+		pkg:    "foo",
+		ident:  "bar",
+		obj:    "bar_x86.o",
+		cTypes: cTypes{"event"},
+		out:    &buf,
+	})
+	if err == nil {
+		t.Fatal("output didn't reject -type, which it can't honor")
+	}
+}